@@ -0,0 +1,70 @@
+package fasttemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"net/url"
+)
+
+// FilterFunc transforms a tag's resolved value before it is written to the
+// output. It is registered under a name with RegisterFilter and applied
+// from a tag's filter chain, e.g. {{user.name | upper}}.
+type FilterFunc func(b []byte) ([]byte, error)
+
+// RegisterFilter registers fn under name, making it available to this
+// template's tags as {{tag | name}}, shadowing any built-in filter of the
+// same name.
+//
+// RegisterFilter is not safe to call concurrently with Execute* or with
+// other RegisterFilter calls; register all filters before executing t.
+func (t *Template) RegisterFilter(name string, fn FilterFunc) {
+	if t.filters == nil {
+		t.filters = make(map[string]FilterFunc)
+	}
+	t.filters[name] = fn
+}
+
+func (t *Template) lookupFilter(name string) FilterFunc {
+	if fn, ok := t.filters[name]; ok {
+		return fn
+	}
+	return builtinFilters[name]
+}
+
+// builtinFilters are the filters available on every Template without an
+// explicit RegisterFilter call.
+var builtinFilters = map[string]FilterFunc{
+	"upper":      filterUpper,
+	"lower":      filterLower,
+	"trim":       filterTrim,
+	"html":       filterHTML,
+	"urlquery":   filterURLQuery,
+	"jsonstring": filterJSONString,
+}
+
+func filterUpper(b []byte) ([]byte, error) {
+	return bytes.ToUpper(b), nil
+}
+
+func filterLower(b []byte) ([]byte, error) {
+	return bytes.ToLower(b), nil
+}
+
+func filterTrim(b []byte) ([]byte, error) {
+	return bytes.TrimSpace(b), nil
+}
+
+func filterHTML(b []byte) ([]byte, error) {
+	return []byte(html.EscapeString(string(b))), nil
+}
+
+func filterURLQuery(b []byte) ([]byte, error) {
+	return []byte(url.QueryEscape(string(b))), nil
+}
+
+// filterJSONString returns b as a double-quoted, escaped JSON string
+// literal, e.g. turning O'Brien into "O'Brien" and a newline into \n.
+func filterJSONString(b []byte) ([]byte, error) {
+	return json.Marshal(string(b))
+}
@@ -0,0 +1,143 @@
+package fasttemplate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestTemplateFilters(t *testing.T) {
+	tpl := New("{{name | upper}}", "{{", "}}")
+	s := tpl.ExecuteString(map[string]interface{}{"name": "john"})
+	if s != "JOHN" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestTemplateFilterChain(t *testing.T) {
+	tpl := New("{{name | trim | upper}}", "{{", "}}")
+	s := tpl.ExecuteString(map[string]interface{}{"name": "  john  "})
+	if s != "JOHN" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestTemplateFilterDefault(t *testing.T) {
+	tpl := New(`{{user | default "anonymous"}}`, "{{", "}}")
+
+	s := tpl.ExecuteString(map[string]interface{}{"user": "John"})
+	if s != "John" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+
+	s = tpl.ExecuteString(map[string]interface{}{})
+	if s != "anonymous" {
+		t.Fatalf("unexpected result for missing tag: %q", s)
+	}
+
+	s = tpl.ExecuteString(map[string]interface{}{"user": ""})
+	if s != "anonymous" {
+		t.Fatalf("unexpected result for empty tag: %q", s)
+	}
+}
+
+func TestTemplateFilterAndDefaultCombined(t *testing.T) {
+	tpl := New(`{{user | upper | default "anonymous"}}`, "{{", "}}")
+
+	s := tpl.ExecuteString(map[string]interface{}{"user": "john"})
+	if s != "JOHN" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+
+	s = tpl.ExecuteString(map[string]interface{}{})
+	if s != "anonymous" {
+		t.Fatalf("unexpected result for missing tag: %q", s)
+	}
+}
+
+func TestTemplateFilterBuiltins(t *testing.T) {
+	tests := []struct {
+		filter string
+		input  string
+		want   string
+	}{
+		{"html", `<b>"hi"</b>`, "&lt;b&gt;&#34;hi&#34;&lt;/b&gt;"},
+		{"urlquery", "a b/c", "a+b%2Fc"},
+		{"jsonstring", `say "hi"`, `"say \"hi\""`},
+	}
+
+	for _, tt := range tests {
+		tpl := New("{{v | "+tt.filter+"}}", "{{", "}}")
+		s := tpl.ExecuteString(map[string]interface{}{"v": tt.input})
+		if s != tt.want {
+			t.Errorf("filter %q: got %q, want %q", tt.filter, s, tt.want)
+		}
+	}
+}
+
+func TestTemplateRegisterFilterOverride(t *testing.T) {
+	tpl := New("{{v | shout}}", "{{", "}}")
+	tpl.RegisterFilter("shout", func(b []byte) ([]byte, error) {
+		return append(b, '!'), nil
+	})
+
+	s := tpl.ExecuteString(map[string]interface{}{"v": "hi"})
+	if s != "hi!" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestTemplateFilterUnregistered(t *testing.T) {
+	tpl := New("{{v | nosuchfilter}}", "{{", "}}")
+	err := tpl.Execute(io.Discard, map[string]interface{}{"v": "hi"})
+	if err == nil {
+		t.Fatal("expected an error for an unregistered filter")
+	}
+}
+
+func TestTemplateFilterColonArgRejected(t *testing.T) {
+	for _, tpl := range []string{
+		"{{v | pad : 10}}",
+		"{{v | pad:10}}",
+	} {
+		if _, err := NewTemplate(tpl, "{{", "}}"); err == nil {
+			t.Errorf("expected a parse error for %q, an argument-taking filter is not supported", tpl)
+		}
+	}
+}
+
+func TestTemplateFilterConcurrentExecute(t *testing.T) {
+	tpl := New("{{name | upper}}", "{{", "}}")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("user%d", i)
+			want := fmt.Sprintf("USER%d", i)
+			for j := 0; j < 100; j++ {
+				if s := tpl.ExecuteString(map[string]interface{}{"name": name}); s != want {
+					t.Errorf("unexpected result: got %q, want %q", s, want)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTemplateFilterErrorPropagates(t *testing.T) {
+	errTest := errors.New("test error")
+	tpl := New("{{v | boom}}", "{{", "}}")
+	tpl.RegisterFilter("boom", func(b []byte) ([]byte, error) {
+		return nil, errTest
+	})
+
+	err := tpl.Execute(io.Discard, map[string]interface{}{"v": "hi"})
+	if !errors.Is(err, errTest) {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
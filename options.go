@@ -0,0 +1,164 @@
+package fasttemplate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Delim is a single tag start/end pair recognised by NewTemplateWithOptions.
+type Delim struct {
+	Start string
+	End   string
+}
+
+// Options configures NewTemplateWithOptions.
+type Options struct {
+	// Delims lists the delim pairs recognised in the template. Each tag
+	// is opened by the pair whose start tag occurs earliest in the
+	// remaining template; where several pairs' start tags open at the
+	// same position, the pair listed first in Delims wins. At least one
+	// pair must be given.
+	Delims []Delim
+
+	// Escape, when true, turns a delim's start tag immediately followed
+	// by itself (e.g. "{{{{" for the "{{" start tag) into a single
+	// literal occurrence of that start tag instead of opening a tag.
+	Escape bool
+}
+
+// ParseError describes a problem found while parsing a template, pinpointing
+// the offending location so that callers such as linters or editors can
+// report it precisely instead of matching against an error string.
+type ParseError struct {
+	Line    int    // 1-based line number of the error
+	Col     int    // 1-based rune column of the error on its line
+	Offset  int    // byte offset of the error within the template
+	Snippet string // a short excerpt of the template around Offset
+
+	msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("fasttemplate: %s at line %d, col %d (offset %d): %q", e.msg, e.Line, e.Col, e.Offset, e.Snippet)
+}
+
+// NewTemplateWithOptions parses the given template according to opts, which
+// may configure several alternative start/end tag pairs - chosen per
+// occurrence - and a doubled-start-tag escape sequence for emitting a
+// literal delim.
+//
+// The returned template can be executed by concurrently running goroutines
+// using Execute* methods.
+//
+// NewTemplateWithOptions panics if opts.Delims is empty or contains a pair
+// with an empty Start or End. Parse failures, such as a tag missing its end
+// delim, are reported through a *ParseError.
+func NewTemplateWithOptions(template string, opts Options) (*Template, error) {
+	if len(opts.Delims) == 0 {
+		panic("fasttemplate: at least one Delim must be given")
+	}
+	for _, d := range opts.Delims {
+		if len(d.Start) == 0 {
+			panic("fasttemplate: startTag cannot be empty")
+		}
+		if len(d.End) == 0 {
+			panic("fasttemplate: endTag cannot be empty")
+		}
+	}
+
+	var t Template
+	var text []byte // literal segment currently being accumulated
+
+	rest := template
+	offset := 0
+
+	for {
+		idx, delim, found := nextStartTag(rest, opts.Delims)
+		if !found {
+			text = append(text, rest...)
+			break
+		}
+
+		if opts.Escape && strings.HasPrefix(rest[idx+len(delim.Start):], delim.Start) {
+			skip := idx + 2*len(delim.Start)
+			text = append(text, rest[:idx+len(delim.Start)]...)
+			rest = rest[skip:]
+			offset += skip
+			continue
+		}
+
+		text = append(text, rest[:idx]...)
+		t.texts = append(t.texts, text)
+		text = nil
+
+		tagOffset := offset + idx + len(delim.Start)
+		rest = rest[idx+len(delim.Start):]
+
+		end := strings.Index(rest, delim.End)
+		if end < 0 {
+			return nil, newParseError(template, tagOffset, fmt.Sprintf("missing end tag %q", delim.End))
+		}
+
+		name, meta, err := parseTagSpec(rest[:end])
+		if err != nil {
+			return nil, newParseError(template, tagOffset, fmt.Sprintf("cannot parse tag: %s", err))
+		}
+		t.tags = append(t.tags, name)
+		t.tagMeta = append(t.tagMeta, meta)
+
+		rest = rest[end+len(delim.End):]
+		offset = tagOffset + end + len(delim.End)
+	}
+
+	if len(t.tags) == 0 {
+		t.template = text
+		t.texts = nil
+		return &t, nil
+	}
+
+	t.texts = append(t.texts, text)
+	return &t, nil
+}
+
+// nextStartTag finds the earliest occurrence in s of any delim's Start tag,
+// returning the winning delim and its index, or found=false if none occurs.
+func nextStartTag(s string, delims []Delim) (idx int, delim Delim, found bool) {
+	idx = -1
+	for _, d := range delims {
+		if i := strings.Index(s, d.Start); i >= 0 && (idx == -1 || i < idx) {
+			idx, delim = i, d
+		}
+	}
+	return idx, delim, idx >= 0
+}
+
+// newParseError builds a ParseError pinpointing the byte offset off within
+// template.
+func newParseError(template string, off int, msg string) *ParseError {
+	line, col := 1, 1
+	for _, r := range template[:off] {
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+
+	const radius = 16
+	start, end := off-radius, off+radius
+	if start < 0 {
+		start = 0
+	}
+	if end > len(template) {
+		end = len(template)
+	}
+
+	return &ParseError{
+		Line:    line,
+		Col:     col,
+		Offset:  off,
+		Snippet: template[start:end],
+		msg:     msg,
+	}
+}
@@ -0,0 +1,94 @@
+package fasttemplate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewTemplateWithOptionsMultipleDelims(t *testing.T) {
+	tpl, err := NewTemplateWithOptions("{{a}} and ${b}", Options{
+		Delims: []Delim{{Start: "{{", End: "}}"}, {Start: "${", End: "}"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	s := tpl.ExecuteString(map[string]interface{}{"a": "foo", "b": "bar"})
+	if s != "foo and bar" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestNewTemplateWithOptionsEscape(t *testing.T) {
+	tpl, err := NewTemplateWithOptions("{{{{literal}} {{tag}}", Options{
+		Delims: []Delim{{Start: "{{", End: "}}"}},
+		Escape: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	s := tpl.ExecuteString(map[string]interface{}{"tag": "value"})
+	if s != "{{literal}} value" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestNewTemplateWithOptionsEscapeDisabledByDefault(t *testing.T) {
+	tpl, err := NewTemplateWithOptions("{{{{tag}}", Options{
+		Delims: []Delim{{Start: "{{", End: "}}"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Without Escape, "{{{{tag}}" is just the tag "{{tag" wrapped in the
+	// usual "{{"/"}}" pair, preceded by an empty literal segment.
+	s := tpl.ExecuteString(map[string]interface{}{"{{tag": "value"})
+	if s != "value" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestNewTemplateWithOptionsParseError(t *testing.T) {
+	_, err := NewTemplateWithOptions("hello {{unterminated", Options{
+		Delims: []Delim{{Start: "{{", End: "}}"}},
+	})
+	if err == nil {
+		t.Fatal("expected a parse error")
+	}
+
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a *ParseError, got %T: %s", err, err)
+	}
+	if perr.Offset != len("hello {{") {
+		t.Fatalf("unexpected offset: %d", perr.Offset)
+	}
+	if perr.Line != 1 || perr.Col != len("hello {{")+1 {
+		t.Fatalf("unexpected position: line=%d col=%d", perr.Line, perr.Col)
+	}
+}
+
+func TestNewTemplateWithOptionsParseErrorLineCol(t *testing.T) {
+	_, err := NewTemplateWithOptions("line1\nline2 {{bad", Options{
+		Delims: []Delim{{Start: "{{", End: "}}"}},
+	})
+
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a *ParseError, got %T: %s", err, err)
+	}
+	if perr.Line != 2 {
+		t.Fatalf("unexpected line: %d", perr.Line)
+	}
+}
+
+func TestNewTemplateWithOptionsPanicsOnEmptyDelims(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for empty Delims")
+		}
+	}()
+	NewTemplateWithOptions("foo", Options{})
+}
@@ -0,0 +1,108 @@
+package fasttemplate
+
+import (
+	"bytes"
+	"io"
+)
+
+// ExecuteFuncReader returns an io.Reader that lazily produces the template's
+// expanded output, calling f on each tag (placeholder) occurrence only as
+// the reader reaches it.
+//
+// Unlike ExecuteFuncBytes and ExecuteFuncString, no full result buffer is
+// built up front, so the returned reader can be fed straight into
+// io.Copy, a chunked http.ResponseWriter or an io.MultiReader chain. A
+// TagFunc error is returned from Read once the reader reaches that tag.
+//
+// The returned reader is not safe for concurrent use.
+func (t *Template) ExecuteFuncReader(f TagFunc) io.Reader {
+	return &templateReader{t: t, f: f}
+}
+
+// ExecuteReader substitutes template tags (placeholders) with the
+// corresponding values from the map m and returns an io.Reader producing
+// the result lazily. See ExecuteFuncReader for details.
+//
+// Substitution map m may contain values with the following types:
+//   - []byte - the fastest value type
+//   - string - convenient value type
+//   - TagFunc - flexible value type
+func (t *Template) ExecuteReader(m map[string]interface{}) io.Reader {
+	return t.ExecuteFuncReader(func(w io.Writer, tag string) error {
+		return stdTagFunc(w, tag, m)
+	})
+}
+
+// templateReader walks t.texts and t.tags as a small state machine,
+// pulling bytes from each literal segment in turn and invoking f into a
+// reusable scratch buffer when a tag slot is reached.
+type templateReader struct {
+	t *Template
+	f TagFunc
+
+	idx  int // index of the next tag/text pair to process
+	buf  []byte
+	pos  int
+	done bool
+	err  error
+
+	scratch bytes.Buffer
+}
+
+func (r *templateReader) Read(p []byte) (int, error) {
+	total := 0
+	for total < len(p) {
+		if r.pos >= len(r.buf) {
+			if !r.fill() {
+				break
+			}
+		}
+		n := copy(p[total:], r.buf[r.pos:])
+		r.pos += n
+		total += n
+	}
+	if total > 0 {
+		return total, nil
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 0, io.EOF
+}
+
+// fill loads the next segment of output into r.buf, resetting r.pos to 0.
+// It returns false once the template is exhausted or a TagFunc call fails.
+//
+// A segment that happens to be empty - a tag resolving to nothing, say -
+// does not by itself mean the stream is exhausted, so fill keeps advancing
+// through empty segments until it finds a non-empty one or genuinely runs
+// out of input.
+func (r *templateReader) fill() bool {
+	for !r.done {
+		n := len(r.t.texts) - 1
+		if n == -1 {
+			r.buf, r.pos, r.done = r.t.template, 0, true
+			return len(r.buf) > 0
+		}
+		if r.idx == n {
+			r.buf, r.pos, r.done = r.t.texts[n], 0, true
+			return len(r.buf) > 0
+		}
+
+		r.scratch.Reset()
+		if err := r.t.execTag(&r.scratch, r.f, r.idx); err != nil {
+			r.err, r.done = err, true
+			return false
+		}
+
+		r.buf = append(r.buf[:0], r.t.texts[r.idx]...)
+		r.buf = append(r.buf, r.scratch.Bytes()...)
+		r.pos = 0
+		r.idx++
+
+		if len(r.buf) > 0 {
+			return true
+		}
+	}
+	return false
+}
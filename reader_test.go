@@ -0,0 +1,136 @@
+package fasttemplate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestTemplateExecuteReader(t *testing.T) {
+	template := "Hello, {{user}}! You won {{prize}}!!! {{foobar}}"
+	tpl := New(template, "{{", "}}")
+
+	m := map[string]interface{}{
+		"user":  "John",
+		"prize": []byte("$100500"),
+	}
+	r := tpl.ExecuteReader(m)
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := "Hello, John! You won $100500!!! "
+	if string(data) != expected {
+		t.Fatalf("unexpected result\ngot:  %q\nwant: %q", data, expected)
+	}
+}
+
+func TestTemplateExecuteReaderNoTags(t *testing.T) {
+	tpl := New("plain text, no tags here", "{{", "}}")
+	r := tpl.ExecuteReader(nil)
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(data) != "plain text, no tags here" {
+		t.Fatalf("unexpected result: %q", data)
+	}
+}
+
+func TestTemplateExecuteReaderSmallBuffer(t *testing.T) {
+	template := "a{{x}}b{{y}}c{{z}}d"
+	tpl := New(template, "{{", "}}")
+
+	m := map[string]interface{}{
+		"x": "1",
+		"y": "22",
+		"z": "333",
+	}
+	r := tpl.ExecuteReader(m)
+
+	// Read a single byte at a time to exercise partial segments across
+	// multiple Read calls.
+	var buf bytes.Buffer
+	p := make([]byte, 1)
+	for {
+		n, err := r.Read(p)
+		buf.Write(p[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	expected := "a1b22c333d"
+	if buf.String() != expected {
+		t.Fatalf("unexpected result\ngot:  %q\nwant: %q", buf.String(), expected)
+	}
+}
+
+func TestTemplateExecuteFuncReaderError(t *testing.T) {
+	errTest := errors.New("test error")
+	tpl := New("foo{{bar}}baz", "{{", "}}")
+
+	r := tpl.ExecuteFuncReader(func(w io.Writer, tag string) error {
+		return errTest
+	})
+
+	_, err := io.ReadAll(r)
+	if !errors.Is(err, errTest) {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestTemplateExecuteReaderEmptyTagNotExhaustion(t *testing.T) {
+	tpl := New("{{missing}}hello", "{{", "}}")
+
+	data, err := io.ReadAll(tpl.ExecuteReader(map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("unexpected result: %q", data)
+	}
+}
+
+func TestTemplateExecuteReaderEmptySlotBetweenTags(t *testing.T) {
+	tpl := New("{{a}}{{b}}", "{{", "}}")
+
+	m := map[string]interface{}{"a": "", "b": "XYZ"}
+	data, err := io.ReadAll(tpl.ExecuteReader(m))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(data) != "XYZ" {
+		t.Fatalf("unexpected result: %q", data)
+	}
+}
+
+func TestTemplateExecuteReaderMatchesExecuteString(t *testing.T) {
+	template := "https://{{host}}/?foo={{bar}}{{bar}}&baz={{baz}}"
+	tpl := New(template, "{{", "}}")
+
+	m := map[string]interface{}{
+		"host": "google.com",
+		"bar":  []byte("foobar"),
+	}
+
+	want := tpl.ExecuteString(m)
+
+	r := tpl.ExecuteReader(m)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := fmt.Sprintf("%s", data); got != want {
+		t.Fatalf("unexpected result\ngot:  %q\nwant: %q", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+package fasttemplate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// NewCaseInsensitiveResolver returns a Resolver that resolves tags against m
+// using a case-insensitive comparison of the tag name, so that e.g.
+// {{Host}} and {{HOST}} hit the same entry. This mirrors how Hugo's Params
+// treats keys.
+//
+// The index is built once up front, so Resolve itself is a plain map
+// lookup. If two keys in m differ only in case, which one ends up in the
+// index is unspecified.
+func NewCaseInsensitiveResolver(m map[string]interface{}) Resolver {
+	idx := make(caseInsensitiveResolver, len(m))
+	for k, v := range m {
+		idx[strings.ToLower(k)] = v
+	}
+	return idx
+}
+
+type caseInsensitiveResolver map[string]interface{}
+
+func (r caseInsensitiveResolver) Resolve(tag string) (interface{}, error) {
+	return r[strings.ToLower(tag)], nil
+}
+
+// NewPathResolver returns a Resolver that resolves dotted tag names such as
+// {{user.address.city}} by walking m one path segment at a time through
+// nested map[string]interface{} and map[string]string values and exported
+// struct fields, matching each segment against a field of the same name
+// case-insensitively (so "city" matches a "City" field).
+//
+// Any segment that doesn't resolve - a missing map key, an unexported or
+// absent struct field, or indexing into a value that is none of the above -
+// makes the whole path resolve to a nil value rather than an error. The same
+// holds for a leaf value Execute can't render directly, e.g. an int or bool
+// struct field: it is stringified (via fmt.Stringer's String method, or
+// fmt.Sprint for other scalar kinds) rather than panicking.
+func NewPathResolver(m map[string]interface{}) Resolver {
+	return pathResolver(m)
+}
+
+type pathResolver map[string]interface{}
+
+func (r pathResolver) Resolve(tag string) (interface{}, error) {
+	var cur interface{} = map[string]interface{}(r)
+	for _, seg := range strings.Split(tag, ".") {
+		cur = resolvePathSegment(cur, seg)
+		if cur == nil {
+			break
+		}
+	}
+	return renderableValue(cur), nil
+}
+
+// renderableValue coerces v into one of the types Execute's resolverTagFunc
+// accepts - nil, []byte, string or TagFunc - so a resolved leaf of an
+// ordinary scalar type is stringified instead of reaching the "unexpected
+// value type" panic.
+func renderableValue(v interface{}) interface{} {
+	switch v.(type) {
+	case nil, []byte, string, TagFunc:
+		return v
+	}
+
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Bool, reflect.String, reflect.Complex64, reflect.Complex128,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v)
+	}
+	return nil
+}
+
+func resolvePathSegment(cur interface{}, seg string) interface{} {
+	switch v := cur.(type) {
+	case map[string]interface{}:
+		return v[seg]
+	case map[string]string:
+		if s, ok := v[seg]; ok {
+			return s
+		}
+		return nil
+	}
+
+	rv := reflect.ValueOf(cur)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if strings.EqualFold(f.Name, seg) {
+			return rv.Field(i).Interface()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package fasttemplate
+
+import "testing"
+
+func TestCaseInsensitiveResolver(t *testing.T) {
+	m := map[string]interface{}{
+		"Host": "google.com",
+		"PORT": "8080",
+	}
+	r := NewCaseInsensitiveResolver(m)
+
+	tpl := New("http://{{host}}:{{Port}}/{{PATH}}", "{{", "}}")
+	s := tpl.ExecuteResolverString(r)
+	if s != "http://google.com:8080/" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestCaseInsensitiveResolver_Ambiguous(t *testing.T) {
+	// Two keys differing only in case collide in the index; either value
+	// is an acceptable outcome, but Resolve must not panic or error.
+	m := map[string]interface{}{
+		"foo": "lower",
+		"FOO": "upper",
+	}
+	r := NewCaseInsensitiveResolver(m)
+
+	v, err := r.Resolve("foo")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v != "lower" && v != "upper" {
+		t.Fatalf("unexpected value: %#v", v)
+	}
+}
+
+func TestPathResolver_Nested(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type User struct {
+		Name    string
+		Address Address
+	}
+
+	m := map[string]interface{}{
+		"user": User{
+			Name:    "John",
+			Address: Address{City: "Springfield"},
+		},
+		"tags": map[string]string{
+			"env": "prod",
+		},
+	}
+	r := NewPathResolver(m)
+
+	tpl := New("{{user.name}} lives in {{user.address.city}} ({{tags.env}})", "{{", "}}")
+	s := tpl.ExecuteResolverString(r)
+	if s != "John lives in Springfield (prod)" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestPathResolver_MissingSegmentShadowing(t *testing.T) {
+	// "user" is a plain string here, shadowing the nested-struct case
+	// from TestPathResolver_Nested; indexing further into it must yield
+	// an empty value rather than panicking.
+	m := map[string]interface{}{
+		"user": "John",
+	}
+	r := NewPathResolver(m)
+
+	tpl := New("{{user}}/{{user.name}}/{{missing.path}}", "{{", "}}")
+	s := tpl.ExecuteResolverString(r)
+	if s != "John//" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestPathResolver_TypeSwitch(t *testing.T) {
+	type Inner struct {
+		unexported string
+		Value      string
+	}
+
+	m := map[string]interface{}{
+		"a": map[string]interface{}{"b": "via-map-interface"},
+		"c": map[string]string{"d": "via-map-string"},
+		"e": Inner{unexported: "hidden", Value: "via-struct"},
+	}
+	r := NewPathResolver(m)
+
+	tpl := New("{{a.b}}|{{c.d}}|{{e.value}}|{{e.unexported}}", "{{", "}}")
+	s := tpl.ExecuteResolverString(r)
+	if s != "via-map-interface|via-map-string|via-struct|" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
+
+func TestPathResolver_ScalarLeaf(t *testing.T) {
+	type User struct {
+		Age    int
+		Active bool
+	}
+
+	m := map[string]interface{}{
+		"user": User{Age: 30, Active: true},
+	}
+	r := NewPathResolver(m)
+
+	tpl := New("{{user.age}}/{{user.active}}", "{{", "}}")
+	s := tpl.ExecuteResolverString(r)
+	if s != "30/true" {
+		t.Fatalf("unexpected result: %q", s)
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // Template implements simple template engine, which can be used for fast
@@ -19,6 +21,9 @@ type Template struct {
 	template []byte
 	texts    [][]byte
 	tags     []string
+	tagMeta  []*tagMeta
+
+	filters map[string]FilterFunc
 }
 
 // New parses the given template using the given startTag and endTag
@@ -40,8 +45,19 @@ func New(template, startTag, endTag string) *Template {
 // NewTemplate parses the given template using the given startTag and endTag
 // as tag start and tag end.
 //
+// A tag may carry a pipe-separated chain of filters and a default value,
+// e.g. {{user.name | upper | default "anonymous"}}: the name is resolved
+// as usual, each filter is applied to the result in turn via its
+// FilterFunc, either a built-in one or one registered with
+// RegisterFilter, and "default" substitutes its quoted
+// argument when the resolved value is missing or empty.
+//
 // The returned template can be executed by concurrently running goroutines
 // using Execute* methods.
+//
+// NewTemplate is a shorthand for NewTemplateWithOptions with a single
+// delim pair and no escaping; see NewTemplateWithOptions for templates
+// that need several alternative delims or a literal-delim escape.
 func NewTemplate(template, startTag, endTag string) (*Template, error) {
 	if len(startTag) == 0 {
 		panic("fasttemplate: startTag cannot be empty")
@@ -50,43 +66,106 @@ func NewTemplate(template, startTag, endTag string) (*Template, error) {
 		panic("fasttemplate: endTag cannot be empty")
 	}
 
-	s := []byte(template)
-	st := template
+	return NewTemplateWithOptions(template, Options{
+		Delims: []Delim{{Start: startTag, End: endTag}},
+	})
+}
 
-	var t Template
+// tagMeta holds the parsed filter chain and default value for a single tag,
+// or nil for the common case of a tag with neither.
+type tagMeta struct {
+	filters []string
+	def     []byte
+	hasDef  bool
+}
 
-	tagsCount := strings.Count(template, startTag)
-	if tagsCount == 0 {
-		t.template = s
-		return &t, nil
+// parseTagSpec splits a raw tag body such as
+// `user.name | upper | default "anonymous"` into the bare name used for
+// value resolution and the filter chain/default to apply to the result.
+// A tag with no "|" returns a nil *tagMeta unchanged, so plain tags keep
+// costing nothing beyond the lookup itself.
+func parseTagSpec(raw string) (string, *tagMeta, error) {
+	if !strings.Contains(raw, "|") {
+		return raw, nil, nil
 	}
 
-	t.texts = make([][]byte, 0, tagsCount+1)
-	t.tags = make([]string, 0, tagsCount)
+	parts := strings.Split(raw, "|")
+	name := strings.TrimSpace(parts[0])
+
+	meta := &tagMeta{}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
 
-	for {
-		n := strings.Index(st, startTag)
-		if n < 0 {
-			t.texts = append(t.texts, s)
-			break
+		filterName, arg, hasArg := part, "", false
+		if i := strings.IndexAny(part, " \t"); i >= 0 {
+			filterName, arg = part[:i], strings.TrimSpace(part[i+1:])
+			hasArg = true
 		}
-		t.texts = append(t.texts, s[:n])
 
-		s = s[n+len(startTag):]
-		st = st[n+len(startTag):]
+		if filterName == "default" {
+			if !hasArg {
+				return "", nil, fmt.Errorf("default filter requires a quoted fallback value")
+			}
+			def, err := strconv.Unquote(arg)
+			if err != nil {
+				def = strings.Trim(arg, `"`)
+			}
+			meta.def, meta.hasDef = []byte(def), true
+			continue
+		}
 
-		n = strings.Index(st, endTag)
-		if n < 0 {
-			return nil, fmt.Errorf("fasttemplate: missing end tag=%q in template=%q starting from %q", endTag, template, s)
+		// Filters registered via RegisterFilter take no arguments, so a
+		// colon-arg form such as "filter : arg" (with or without the
+		// space) is rejected rather than silently running the filter
+		// with the argument dropped on the floor.
+		if hasArg || strings.Contains(filterName, ":") {
+			return "", nil, fmt.Errorf("filter %q does not take arguments", filterName)
 		}
 
-		t.tags = append(t.tags, st[:n])
+		meta.filters = append(meta.filters, filterName)
+	}
+
+	return name, meta, nil
+}
+
+// execTag resolves and writes the i-th tag, applying its filter chain and
+// default value, if any, on top of whatever f writes.
+func (t *Template) execTag(w io.Writer, f TagFunc, i int) error {
+	meta := t.tagMeta[i]
+	if meta == nil {
+		return f(w, t.tags[i])
+	}
+
+	scratch := bufferPool.Get().(*bytes.Buffer)
+	scratch.Reset()
+	defer bufferPool.Put(scratch)
+
+	if err := f(scratch, t.tags[i]); err != nil {
+		return err
+	}
 
-		s = s[n+len(endTag):]
-		st = st[n+len(endTag):]
+	b := scratch.Bytes()
+	if len(b) == 0 && meta.hasDef {
+		b = meta.def
+	} else {
+		for _, name := range meta.filters {
+			fn := t.lookupFilter(name)
+			if fn == nil {
+				return fmt.Errorf("fasttemplate: tag=%q uses unregistered filter %q", t.tags[i], name)
+			}
+
+			var err error
+			if b, err = fn(b); err != nil {
+				return err
+			}
+		}
 	}
 
-	return &t, nil
+	_, err := w.Write(b)
+	return err
 }
 
 // TagFunc can be used as a substitution value in the map passed to Execute*.
@@ -109,7 +188,7 @@ func (t *Template) ExecuteFunc(w io.Writer, f TagFunc) error {
 			return err
 		}
 
-		if err := f(w, t.tags[i]); err != nil {
+		if err := t.execTag(w, f, i); err != nil {
 			return err
 		}
 	}
@@ -131,17 +210,32 @@ func (t *Template) Execute(w io.Writer, m map[string]interface{}) error {
 	})
 }
 
+// bufferPool holds *bytes.Buffer values reused across ExecuteFuncBytes,
+// ExecuteFuncString and execTag's filter/default handling, so the hot
+// substitution path doesn't grow and discard a fresh buffer on every call.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
 // ExecuteFuncBytes calls f on each template tag (placeholder) occurrence
 // and substitutes it with the data written to TagFunc's w.
 //
 // Returns the resulting byte slice.
 func (t *Template) ExecuteFuncBytes(f TagFunc) []byte {
-	var buf bytes.Buffer
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
 	buf.Grow(len(t.template))
-	if err := t.ExecuteFunc(&buf, f); err != nil {
+
+	err := t.ExecuteFunc(buf, f)
+	b := append([]byte(nil), buf.Bytes()...)
+	bufferPool.Put(buf)
+
+	if err != nil {
 		panic(fmt.Sprintf("fasttemplate: unexpected error: %s", err))
 	}
-	return buf.Bytes()
+	return b
 }
 
 // ExecuteBytes substitutes template tags (placeholders) with the corresponding
@@ -162,12 +256,18 @@ func (t *Template) ExecuteBytes(m map[string]interface{}) []byte {
 //
 // Returns the resulting string.
 func (t *Template) ExecuteFuncString(f TagFunc) string {
-	var sb strings.Builder
-	sb.Grow(len(t.template))
-	if err := t.ExecuteFunc(&sb, f); err != nil {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	buf.Grow(len(t.template))
+
+	err := t.ExecuteFunc(buf, f)
+	s := buf.String()
+	bufferPool.Put(buf)
+
+	if err != nil {
 		panic(fmt.Sprintf("fasttemplate: unexpected error: %s", err))
 	}
-	return sb.String()
+	return s
 }
 
 // ExecuteString substitutes template tags (placeholders) with the corresponding
@@ -183,8 +283,64 @@ func (t *Template) ExecuteString(m map[string]interface{}) string {
 	})
 }
 
-func stdTagFunc(w io.Writer, tag string, m map[string]interface{}) error {
-	v := m[tag]
+// ExecuteStringTo substitutes template tags (placeholders) with the
+// corresponding values from the map m and appends the result to dst,
+// returning the extended buffer.
+//
+// ExecuteStringTo writes the result directly into dst's backing array
+// rather than into a separate buffer that is then copied, so callers that
+// keep reusing the same dst across calls (e.g. by truncating it to
+// dst[:0] before the next call) get no new result buffer per call once
+// dst has enough capacity. It is not allocation-free: each call still
+// allocates the bytes.Buffer that wraps dst.
+//
+// Substitution map m may contain values with the following types:
+//   * []byte - the fastest value type
+//   * string - convenient value type
+//   * TagFunc - flexible value type
+func (t *Template) ExecuteStringTo(dst []byte, m map[string]interface{}) []byte {
+	buf := bytes.NewBuffer(dst)
+	if err := t.ExecuteFunc(buf, func(w io.Writer, tag string) error {
+		return stdTagFunc(w, tag, m)
+	}); err != nil {
+		panic(fmt.Sprintf("fasttemplate: unexpected error: %s", err))
+	}
+	return buf.Bytes()
+}
+
+// Resolver resolves the value to substitute for a tag name, providing an
+// extension point for custom name-matching strategies in place of the
+// default exact-match map[string]interface{} lookup used by Execute.
+//
+// Resolve must be safe to call from concurrently running goroutines.
+type Resolver interface {
+	// Resolve returns the value for the given tag, or a nil value if the
+	// tag has no corresponding value. The returned value must be of one
+	// of the types accepted by Execute: []byte, string or TagFunc.
+	Resolve(tag string) (interface{}, error)
+}
+
+// ExecuteResolver calls r.Resolve on each template tag (placeholder)
+// occurrence and writes the result to w.
+func (t *Template) ExecuteResolver(w io.Writer, r Resolver) error {
+	return t.ExecuteFunc(w, func(w io.Writer, tag string) error {
+		return resolverTagFunc(w, tag, r)
+	})
+}
+
+// ExecuteResolverString calls r.Resolve on each template tag (placeholder)
+// occurrence and returns the resulting string.
+func (t *Template) ExecuteResolverString(r Resolver) string {
+	return t.ExecuteFuncString(func(w io.Writer, tag string) error {
+		return resolverTagFunc(w, tag, r)
+	})
+}
+
+func resolverTagFunc(w io.Writer, tag string, r Resolver) error {
+	v, err := r.Resolve(tag)
+	if err != nil {
+		return err
+	}
 	if v == nil {
 		return nil
 	}
@@ -201,3 +357,16 @@ func stdTagFunc(w io.Writer, tag string, m map[string]interface{}) error {
 		panic(fmt.Sprintf("fasttemplate: tag=%q contains unexpected value type=%#v", tag, v))
 	}
 }
+
+// mapResolver is the default Resolver used by Execute, ExecuteBytes and
+// ExecuteString. It performs an exact-match lookup in the given map, which
+// was the only substitution strategy available before Resolver existed.
+type mapResolver map[string]interface{}
+
+func (m mapResolver) Resolve(tag string) (interface{}, error) {
+	return m[tag], nil
+}
+
+func stdTagFunc(w io.Writer, tag string, m map[string]interface{}) error {
+	return resolverTagFunc(w, tag, mapResolver(m))
+}
@@ -0,0 +1,51 @@
+package fasttemplate
+
+import "testing"
+
+func TestTemplateExecuteStringTo(t *testing.T) {
+	tpl := New("Hello, {{user}}!", "{{", "}}")
+	m := map[string]interface{}{"user": "John"}
+
+	dst := make([]byte, 0, 64)
+	dst = append(dst, "prefix: "...)
+	dst = tpl.ExecuteStringTo(dst, m)
+
+	expected := "prefix: Hello, John!"
+	if string(dst) != expected {
+		t.Fatalf("unexpected result\ngot:  %q\nwant: %q", dst, expected)
+	}
+}
+
+func TestTemplateExecuteStringToReuse(t *testing.T) {
+	tpl := New("{{a}}-{{b}}", "{{", "}}")
+
+	dst := make([]byte, 0, 64)
+	for i, m := range []map[string]interface{}{
+		{"a": "1", "b": "22"},
+		{"a": "333", "b": "4"},
+	} {
+		dst = tpl.ExecuteStringTo(dst[:0], m)
+		if string(dst) != []string{"1-22", "333-4"}[i] {
+			t.Fatalf("unexpected result on iteration %d: %q", i, dst)
+		}
+	}
+}
+
+func TestTemplateExecuteBytesPoolReuse(t *testing.T) {
+	tpl := New("foo{{bar}}baz", "{{", "}}")
+	m := map[string]interface{}{"bar": "BAR"}
+
+	// Results returned from separate calls must not alias the same
+	// underlying array, since the scratch buffer is recycled through a
+	// pool between calls.
+	a := tpl.ExecuteBytes(m)
+	b := tpl.ExecuteBytes(m)
+
+	a[0] = 'X'
+	if string(b) != "fooBARbaz" {
+		t.Fatalf("mutating one result corrupted another: %q", b)
+	}
+	if string(a) != "XooBARbaz" {
+		t.Fatalf("unexpected result: %q", a)
+	}
+}